@@ -0,0 +1,31 @@
+package lifecycle
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// AppDataDir returns the per-user directory the desktop app and server use
+// for logs and other runtime state. It does not create the directory.
+func AppDataDir() string {
+	var dir string
+	switch runtime.GOOS {
+	case "windows":
+		dir = filepath.Join(os.Getenv("LOCALAPPDATA"), "Ollama")
+	case "darwin":
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, "Library", "Application Support", "Ollama")
+	default:
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".ollama", "logs")
+	}
+	return dir
+}
+
+// ServerLogFile and AppLogFile are the well-known log paths consulted by
+// `ollama logs` and the desktop app's log viewer.
+var (
+	ServerLogFile = filepath.Join(AppDataDir(), "server.log")
+	AppLogFile    = filepath.Join(AppDataDir(), "app.log")
+)
@@ -0,0 +1,238 @@
+package lifecycle
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogConfig controls rotation of the server's log file: once it grows past
+// MaxSizeMB it is renamed aside and a fresh file is opened in its place, and
+// old files beyond MaxBackups or older than MaxAgeDays are pruned.
+type LogConfig struct {
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	Compress   bool
+}
+
+// DefaultLogConfig is used for any OLLAMA_LOG_* environment variable that is
+// unset or fails to parse.
+var DefaultLogConfig = LogConfig{
+	MaxSizeMB:  100,
+	MaxBackups: 5,
+	MaxAgeDays: 0,
+	Compress:   false,
+}
+
+// LogConfigFromEnv builds a LogConfig from OLLAMA_LOG_MAX_SIZE (megabytes),
+// OLLAMA_LOG_MAX_BACKUPS, OLLAMA_LOG_MAX_AGE (days), and OLLAMA_LOG_COMPRESS.
+func LogConfigFromEnv() LogConfig {
+	cfg := DefaultLogConfig
+	if v := os.Getenv("OLLAMA_LOG_MAX_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.MaxSizeMB = n
+		}
+	}
+	if v := os.Getenv("OLLAMA_LOG_MAX_BACKUPS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.MaxBackups = n
+		}
+	}
+	if v := os.Getenv("OLLAMA_LOG_MAX_AGE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.MaxAgeDays = n
+		}
+	}
+	if v := os.Getenv("OLLAMA_LOG_COMPRESS"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Compress = b
+		}
+	}
+	return cfg
+}
+
+func (c LogConfig) maxSizeBytes() int64 {
+	return int64(c.MaxSizeMB) * 1024 * 1024
+}
+
+// RotatingWriter is an io.WriteCloser that appends to path, rotating to a
+// fresh file once the current one exceeds cfg.MaxSizeMB. Rotation renames
+// the current file aside rather than truncating it, so a lifecycle.Tail
+// follower watching path sees a rename+recreate and swaps to the new handle
+// through its own rotation detection without losing any lines.
+type RotatingWriter struct {
+	path string
+	cfg  LogConfig
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+// NewRotatingWriter opens path for appending, creating it and its parent
+// directory if necessary, and returns a writer that rotates per cfg. This is
+// the hook a server main wires its log output through in place of a plain
+// os.OpenFile, so the handle it holds is swapped atomically on rotation.
+func NewRotatingWriter(path string, cfg LogConfig) (*RotatingWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	return &RotatingWriter{path: path, cfg: cfg, f: f, size: info.Size()}, nil
+}
+
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cfg.MaxSizeMB > 0 && w.size > 0 && w.size+int64(len(p)) > w.cfg.maxSizeBytes() {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.f.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the underlying file handle.
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.f.Close()
+}
+
+// rotate renames the current file aside with a timestamp suffix, opens a
+// fresh file at the original path, and prunes old backups. Callers must
+// hold w.mu.
+func (w *RotatingWriter) rotate() error {
+	if err := w.f.Close(); err != nil {
+		return fmt.Errorf("failed to close log file before rotation: %w", err)
+	}
+
+	backup := backupPath(w.path, time.Now())
+	if err := os.Rename(w.path, backup); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	if w.cfg.Compress {
+		if err := compressBackup(backup); err != nil {
+			return fmt.Errorf("failed to compress rotated log file: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file after rotation: %w", err)
+	}
+	w.f = f
+	w.size = 0
+
+	return pruneBackups(w.path, w.cfg)
+}
+
+func backupPath(path string, t time.Time) string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	return fmt.Sprintf("%s-%s%s", base, t.Format("20060102T150405.000000000"), ext)
+}
+
+func backupGlob(path string) string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	return base + "-*" + ext + "*"
+}
+
+func compressBackup(backup string) error {
+	in, err := os.Open(backup)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(backup + ".gz")
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		gz.Close()
+		out.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Remove(backup)
+}
+
+// pruneBackups removes rotated files beyond cfg.MaxBackups (keeping the
+// newest) and any older than cfg.MaxAgeDays, whichever limits are set.
+func pruneBackups(path string, cfg LogConfig) error {
+	if cfg.MaxBackups <= 0 && cfg.MaxAgeDays <= 0 {
+		return nil
+	}
+
+	matches, err := filepath.Glob(backupGlob(path))
+	if err != nil {
+		return err
+	}
+
+	type backup struct {
+		path    string
+		modTime time.Time
+	}
+	backups := make([]backup, 0, len(matches))
+	for _, m := range matches {
+		info, err := os.Stat(m)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: m, modTime: info.ModTime()})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].modTime.After(backups[j].modTime)
+	})
+
+	cutoff := time.Now().AddDate(0, 0, -cfg.MaxAgeDays)
+	var errs []error
+	for i, b := range backups {
+		tooOld := cfg.MaxAgeDays > 0 && b.modTime.Before(cutoff)
+		tooMany := cfg.MaxBackups > 0 && i >= cfg.MaxBackups
+		if tooOld || tooMany {
+			if err := os.Remove(b.path); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to prune %d old log file(s): %v", len(errs), errs[0])
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package lifecycle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingWriterRotatesPastMaxSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	w, err := NewRotatingWriter(path, LogConfig{MaxSizeMB: 1, MaxBackups: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	line := strings.Repeat("x", 1024) + "\n"
+	for i := 0; i < 1100; i++ { // ~1.1MB, past the 1MB cap
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+
+	matches, err := filepath.Glob(backupGlob(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) == 0 {
+		t.Fatal("expected at least one backup file after exceeding MaxSizeMB")
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected fresh log file at original path: %v", err)
+	}
+}
+
+func TestRotatingWriterPrunesOldBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	w, err := NewRotatingWriter(path, LogConfig{MaxSizeMB: 1, MaxBackups: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	line := strings.Repeat("x", 1024) + "\n"
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 1100; i++ {
+			if _, err := w.Write([]byte(line)); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+		}
+		time.Sleep(10 * time.Millisecond) // keep backup mod times distinct
+	}
+
+	matches, err := filepath.Glob(backupGlob(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) > 2 {
+		t.Errorf("expected at most 2 backups to survive pruning, got %d: %v", len(matches), matches)
+	}
+}
+
+// TestTailFollowsRotatingWriter verifies a concurrent lifecycle.Tail follower
+// keeps emitting new lines after RotatingWriter rotates the file out from
+// under it.
+func TestTailFollowsRotatingWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	w, err := NewRotatingWriter(path, LogConfig{MaxSizeMB: 1, MaxBackups: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	var out bytes.Buffer
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Tail(ctx, path, &out, TailOptions{
+			Follow:       true,
+			Rotate:       true,
+			PollInterval: 20 * time.Millisecond,
+		})
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	line := strings.Repeat("x", 1024) + "\n"
+	for i := 0; i < 1100; i++ { // forces a rotation
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	if _, err := fmt.Fprintln(w, "after rotation"); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Tail returned error: %v", err)
+	}
+
+	if !bytes.Contains(out.Bytes(), []byte("after rotation")) {
+		t.Errorf("expected follower to pick up line written after rotation, got %d bytes", out.Len())
+	}
+}
@@ -0,0 +1,31 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// StartServer launches the `ollama serve` subprocess at command, redirecting
+// its stdout and stderr into ServerLogFile through a RotatingWriter
+// configured from LogConfigFromEnv, so the desktop app's managed server
+// process gets a size-capped log instead of the unbounded file a plain
+// os.OpenFile would produce. The caller is responsible for closing the
+// returned writer once cmd has exited.
+func StartServer(ctx context.Context, command string, args ...string) (*exec.Cmd, *RotatingWriter, error) {
+	logWriter, err := NewRotatingWriter(ServerLogFile, LogConfigFromEnv())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open server log: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Stdout = logWriter
+	cmd.Stderr = logWriter
+
+	if err := cmd.Start(); err != nil {
+		logWriter.Close()
+		return nil, nil, fmt.Errorf("failed to start server: %w", err)
+	}
+
+	return cmd, logWriter, nil
+}
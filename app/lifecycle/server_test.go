@@ -0,0 +1,54 @@
+package lifecycle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestStartServerWritesRotatingLog uses the standard self-exec helper-
+// process pattern (this same test binary, re-invoked with an env var that
+// makes it print a line and exit) to stand in for the real `ollama serve`
+// binary, and asserts its output lands in ServerLogFile through
+// NewRotatingWriter rather than an unbounded plain file.
+func TestStartServerWritesRotatingLog(t *testing.T) {
+	if os.Getenv("OLLAMA_TEST_HELPER_PROCESS") == "1" {
+		fmt.Println("hello from server")
+		return
+	}
+
+	path := filepath.Join(t.TempDir(), "server.log")
+	origServerLogFile := ServerLogFile
+	ServerLogFile = path
+	defer func() { ServerLogFile = origServerLogFile }()
+
+	self, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("OLLAMA_TEST_HELPER_PROCESS", "1")
+
+	cmd, logWriter, err := StartServer(context.Background(), self, "-test.run=TestStartServerWritesRotatingLog")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		t.Fatalf("helper process failed: %v", err)
+	}
+	if err := logWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte("hello from server")) {
+		t.Errorf("expected server output in the rotating log, got %q", data)
+	}
+}
@@ -0,0 +1,241 @@
+package lifecycle
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// rotationPrefixLen is how many leading bytes of the tailed file are kept as
+// a fingerprint to recognize truncate-and-rewrite rotation (logrotate's
+// copy-truncate mode and similar), where the file may already have grown
+// past any previously recorded read offset by the time it's next checked,
+// making a plain size comparison unreliable.
+const rotationPrefixLen = 64
+
+// TailOptions configures Tail's rotation-aware follow behavior.
+type TailOptions struct {
+	// Follow keeps streaming new lines until ctx is cancelled.
+	Follow bool
+	// Rotate enables detection of truncation, rename, and remove+recreate
+	// of the path being tailed, same as `tail -F`.
+	Rotate bool
+	// PollInterval bounds how long Tail can go without rechecking path for
+	// rotation when fsnotify isn't available or misses an event. Defaults
+	// to 250ms when zero.
+	PollInterval time.Duration
+}
+
+func (o TailOptions) pollInterval() time.Duration {
+	if o.PollInterval > 0 {
+		return o.PollInterval
+	}
+	return 250 * time.Millisecond
+}
+
+// Tail streams path to w, starting from its current end of file, until ctx
+// is cancelled. With Rotate set it behaves like BSD `tail -F`: the
+// directory containing path is watched via fsnotify where available, with
+// a poll-interval safety net for platforms or filesystems where fsnotify
+// isn't usable, so truncation, rename, and remove+recreate are all followed
+// without losing any bytes written to the file before the rotation.
+//
+// Cancellation never loses trailing output: the final write to path and the
+// caller cancelling ctx can race, so on ctx.Done Tail drains whatever bytes
+// have already landed in the file up to its current size before returning,
+// rather than stopping at whatever it had read on the previous poll.
+func Tail(ctx context.Context, path string, w io.Writer, opts TailOptions) error {
+	f, prefix, err := openAtEnd(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if !opts.Follow {
+		return nil
+	}
+
+	var events <-chan fsnotify.Event
+	var watchErrs <-chan error
+	if watcher, err := newPathWatcher(path); err == nil {
+		defer watcher.Close()
+		events = watcher.Events
+		watchErrs = watcher.Errors
+	}
+
+	ticker := time.NewTicker(opts.pollInterval())
+	defer ticker.Stop()
+
+	reader := bufio.NewReader(f)
+
+	drain := func() int64 {
+		n, _ := io.Copy(w, reader)
+		return n
+	}
+
+	check := func() {
+		if !opts.Rotate {
+			drain()
+			return
+		}
+
+		// Swap to the post-rotation file/reader before draining, so the
+		// drain below emits the fresh content immediately rather than
+		// waiting for another tick. A replaced file's remaining bytes are
+		// drained from the old handle first so they aren't lost.
+		switch action, newFile, newPrefix := checkRotation(path, f, prefix); action {
+		case rotationTruncated:
+			reader = bufio.NewReader(f)
+			prefix = newPrefix
+		case rotationReplaced:
+			drain()
+			f.Close()
+			f = newFile
+			reader = bufio.NewReader(f)
+			prefix = newPrefix
+		}
+		drain()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			drain()
+			return nil
+		case <-ticker.C:
+			check()
+		case _, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			check()
+		case _, ok := <-watchErrs:
+			if !ok {
+				watchErrs = nil
+			}
+			// A watcher error doesn't stop following; the poll ticker
+			// above still covers rotation detection.
+		}
+	}
+}
+
+// newPathWatcher watches the directory containing path so renames and
+// remove+recreate (which don't touch the original inode) are observed in
+// addition to in-place writes and truncation.
+func newPathWatcher(path string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	return watcher, nil
+}
+
+type rotationAction int
+
+const (
+	rotationNone rotationAction = iota
+	rotationTruncated
+	rotationReplaced
+)
+
+func openAtEnd(path string) (*os.File, []byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prefix, err := readPrefixFrom(f, rotationPrefixLen)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	if _, err := f.Seek(0, io.SeekEnd); err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	return f, prefix, nil
+}
+
+// readPrefixFrom reads up to n bytes from f's current position, tolerating
+// a short file (fewer than n bytes) without treating that as an error.
+func readPrefixFrom(f *os.File, n int) ([]byte, error) {
+	buf := make([]byte, n)
+	read, err := io.ReadFull(f, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	return buf[:read], nil
+}
+
+func readPrefix(path string, n int) ([]byte, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	p, err := readPrefixFrom(f, n)
+	if err != nil {
+		return nil, false
+	}
+	return p, true
+}
+
+// checkRotation detects the file at path being replaced (different identity
+// than f, i.e. rename or remove+recreate) or truncated in place. Truncation
+// is recognized by comparing path's current leading bytes against prefix,
+// the fingerprint captured the last time f was known to be in sync: a size
+// check alone misses the common logrotate copy-truncate sequence, where the
+// file can already have grown past any previously recorded offset again by
+// the time this runs.
+func checkRotation(path string, f *os.File, prefix []byte) (rotationAction, *os.File, []byte) {
+	pathInfo, err := os.Stat(path)
+	if err != nil {
+		// Path is mid-rename/removal; keep the old handle open so any
+		// buffered reads still succeed, and try again next check.
+		return rotationNone, nil, prefix
+	}
+
+	curInfo, err := f.Stat()
+	if err != nil {
+		return rotationNone, nil, prefix
+	}
+
+	if os.SameFile(pathInfo, curInfo) {
+		curPrefix, ok := readPrefix(path, len(prefix))
+		if ok && bytes.Equal(curPrefix, prefix) {
+			return rotationNone, nil, prefix
+		}
+
+		// The file's own beginning no longer matches what we last read
+		// from it: it was truncated (and possibly already rewritten)
+		// since the last check. Re-read it from scratch.
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return rotationNone, nil, prefix
+		}
+		newPrefix, _ := readPrefix(path, rotationPrefixLen)
+		return rotationTruncated, nil, newPrefix
+	}
+
+	// A new file now exists at path (rename+recreate, or a fresh log after
+	// logrotate moved the old one aside). Reopen it from the start.
+	newFile, err := os.Open(path)
+	if err != nil {
+		return rotationNone, nil, prefix
+	}
+	newPrefix, _ := readPrefix(path, rotationPrefixLen)
+	return rotationReplaced, newFile, newPrefix
+}
@@ -0,0 +1,51 @@
+package lifecycle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestTailDrainsOnCancel writes N lines immediately before cancelling the
+// context and asserts all N still appear, covering the race between the
+// final write and the follower being torn down.
+func TestTailDrainsOnCancel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Tail(ctx, path, &buf, TailOptions{Follow: true, PollInterval: 20 * time.Millisecond})
+	}()
+	time.Sleep(30 * time.Millisecond)
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const n = 20
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(f, "final line %d\n", i)
+	}
+	f.Close()
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Tail returned error: %v", err)
+	}
+
+	for i := 0; i < n; i++ {
+		want := fmt.Sprintf("final line %d", i)
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("expected output to contain %q, got %q", want, buf.String())
+		}
+	}
+}
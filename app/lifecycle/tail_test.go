@@ -0,0 +1,103 @@
+package lifecycle
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runTail(t *testing.T, path string) (*bytes.Buffer, context.CancelFunc, <-chan error) {
+	t.Helper()
+	var buf bytes.Buffer
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Tail(ctx, path, &buf, TailOptions{Follow: true, Rotate: true, PollInterval: 20 * time.Millisecond})
+	}()
+	time.Sleep(50 * time.Millisecond)
+	return &buf, cancel, done
+}
+
+func TestTailFollowsTruncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	writeFile(t, path, "line one\n")
+
+	buf, cancel, done := runTail(t, path)
+
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("line after truncate\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Tail returned error: %v", err)
+	}
+
+	if !bytes.Contains(buf.Bytes(), []byte("line after truncate")) {
+		t.Fatalf("expected output to contain post-truncate line, got %q", buf.String())
+	}
+}
+
+func TestTailFollowsRename(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	writeFile(t, path, "before rotation\n")
+
+	buf, cancel, done := runTail(t, path)
+
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, path, "after rotation\n")
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Tail returned error: %v", err)
+	}
+
+	if !bytes.Contains(buf.Bytes(), []byte("after rotation")) {
+		t.Fatalf("expected output to contain post-rotation line, got %q", buf.String())
+	}
+}
+
+func TestTailFollowsRemoveRecreate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	writeFile(t, path, "before removal\n")
+
+	buf, cancel, done := runTail(t, path)
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	writeFile(t, path, "after recreate\n")
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Tail returned error: %v", err)
+	}
+
+	if !bytes.Contains(buf.Bytes(), []byte("after recreate")) {
+		t.Fatalf("expected output to contain post-recreate line, got %q", buf.String())
+	}
+}
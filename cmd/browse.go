@@ -0,0 +1,309 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/ollama/ollama/api"
+	"github.com/ollama/ollama/format"
+)
+
+// browseInputMode distinguishes normal list navigation from the inline text
+// prompt used to collect a destination name for "cp".
+type browseInputMode int
+
+const (
+	browseModeList browseInputMode = iota
+	browseModeCopyInput
+)
+
+// browseModel is the bubbletea model backing `ollama browse`. It lists
+// local models, renders showInfo output for the highlighted entry below the
+// list, and exposes keyboard shortcuts to show, run, rm, cp, and push the
+// selected model. The list is loaded at startup and reloaded after an rm or
+// cp; it is not refreshed on a timer, and the detail is not drawn as a
+// side-by-side pane.
+type browseModel struct {
+	ctx     context.Context
+	client  *api.Client
+	models  []api.ListModelResponse
+	cursor  int
+	noColor bool
+	detail  string
+	verbose bool
+	status  string
+	err     error
+
+	mode       browseInputMode
+	copyTarget string
+}
+
+func newBrowseModel(client *api.Client, noColor bool) browseModel {
+	return browseModel{ctx: context.Background(), client: client, noColor: noColor}
+}
+
+func (m browseModel) selected() (api.ListModelResponse, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.models) {
+		return api.ListModelResponse{}, false
+	}
+	return m.models[m.cursor], true
+}
+
+type modelsLoadedMsg struct {
+	models []api.ListModelResponse
+	err    error
+}
+
+type showLoadedMsg struct {
+	text string
+	err  error
+}
+
+// actionDoneMsg reports the result of a run/rm/cp/push action so Update can
+// surface it as a status line and, for rm and cp, refresh the model list.
+type actionDoneMsg struct {
+	verb string
+	name string
+	err  error
+}
+
+func (m browseModel) Init() tea.Cmd {
+	return m.loadModels
+}
+
+func (m browseModel) loadModels() tea.Msg {
+	resp, err := m.client.List(m.ctx)
+	if err != nil {
+		return modelsLoadedMsg{err: err}
+	}
+	return modelsLoadedMsg{models: resp.Models}
+}
+
+func (m browseModel) loadDetail(name string) tea.Cmd {
+	verbose := m.verbose
+	return func() tea.Msg {
+		resp, err := m.client.Show(m.ctx, &api.ShowRequest{Name: name})
+		if err != nil {
+			return showLoadedMsg{err: err}
+		}
+		var b strings.Builder
+		if err := showInfo(resp, verbose, &b); err != nil {
+			return showLoadedMsg{err: err}
+		}
+		return showLoadedMsg{text: b.String()}
+	}
+}
+
+// runSelected suspends the TUI and execs `<self> run <name>` so the model's
+// interactive generate session gets a real terminal, resuming the browser
+// once it exits.
+func (m browseModel) runSelected(name string) tea.Cmd {
+	self, err := os.Executable()
+	if err != nil {
+		self = os.Args[0]
+	}
+	c := exec.Command(self, "run", name)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		return actionDoneMsg{verb: "run", name: name, err: err}
+	})
+}
+
+func (m browseModel) deleteSelected(name string) tea.Cmd {
+	return func() tea.Msg {
+		err := m.client.Delete(m.ctx, &api.DeleteRequest{Name: name})
+		return actionDoneMsg{verb: "rm", name: name, err: err}
+	}
+}
+
+func (m browseModel) copySelected(source, dest string) tea.Cmd {
+	return func() tea.Msg {
+		err := m.client.Copy(m.ctx, &api.CopyRequest{Source: source, Destination: dest})
+		return actionDoneMsg{verb: "cp", name: dest, err: err}
+	}
+}
+
+func (m browseModel) pushSelected(name string) tea.Cmd {
+	return func() tea.Msg {
+		err := m.client.Push(m.ctx, &api.PushRequest{Name: name}, func(api.ProgressResponse) error { return nil })
+		return actionDoneMsg{verb: "push", name: name, err: err}
+	}
+}
+
+func (m browseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case modelsLoadedMsg:
+		m.err = msg.err
+		m.models = msg.models
+		if m.cursor >= len(m.models) {
+			m.cursor = len(m.models) - 1
+		}
+		if len(m.models) > 0 && m.cursor >= 0 {
+			return m, m.loadDetail(m.models[m.cursor].Name)
+		}
+		return m, nil
+	case showLoadedMsg:
+		m.err = msg.err
+		m.detail = msg.text
+		return m, nil
+	case actionDoneMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("%s %s failed: %v", msg.verb, msg.name, msg.err)
+			return m, nil
+		}
+		m.status = fmt.Sprintf("%s %s done", msg.verb, msg.name)
+		if msg.verb == "rm" || msg.verb == "cp" {
+			return m, m.loadModels
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.mode == browseModeCopyInput {
+			return m.updateCopyInput(msg)
+		}
+		return m.updateList(msg)
+	}
+	return m, nil
+}
+
+func (m browseModel) updateCopyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.mode = browseModeList
+		m.copyTarget = ""
+		return m, nil
+	case "enter":
+		src, ok := m.selected()
+		m.mode = browseModeList
+		dest := m.copyTarget
+		m.copyTarget = ""
+		if !ok || dest == "" {
+			return m, nil
+		}
+		return m, m.copySelected(src.Name, dest)
+	case "backspace":
+		if r := []rune(m.copyTarget); len(r) > 0 {
+			m.copyTarget = string(r[:len(r)-1])
+		}
+		return m, nil
+	default:
+		if len(msg.Runes) > 0 {
+			m.copyTarget += string(msg.Runes)
+		}
+		return m, nil
+	}
+}
+
+func (m browseModel) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+			return m, m.loadDetail(m.models[m.cursor].Name)
+		}
+	case "down", "j":
+		if m.cursor < len(m.models)-1 {
+			m.cursor++
+			return m, m.loadDetail(m.models[m.cursor].Name)
+		}
+	case "s":
+		sel, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		m.verbose = !m.verbose
+		return m, m.loadDetail(sel.Name)
+	case "r":
+		sel, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		return m, m.runSelected(sel.Name)
+	case "d":
+		sel, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		return m, m.deleteSelected(sel.Name)
+	case "c":
+		if _, ok := m.selected(); ok {
+			m.mode = browseModeCopyInput
+		}
+		return m, nil
+	case "p":
+		sel, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		return m, m.pushSelected(sel.Name)
+	}
+	return m, nil
+}
+
+func (m browseModel) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("error: %v\n", m.err)
+	}
+
+	var left strings.Builder
+	for i, mod := range m.models {
+		marker := "  "
+		if i == m.cursor {
+			marker = "> "
+			if !m.noColor {
+				marker = "\x1b[32m> \x1b[0m"
+			}
+		}
+		fmt.Fprintf(&left, "%s%-30s %8s\n", marker, mod.Name, format.HumanBytes(mod.Size))
+	}
+
+	out := left.String() + "\n" + m.detail
+	if m.mode == browseModeCopyInput {
+		out += fmt.Sprintf("\ncopy to: %s█", m.copyTarget)
+	} else if m.status != "" {
+		out += "\n" + m.status
+	}
+	return out
+}
+
+// renderBrowseSnapshot renders a single frame of the browse view without a
+// real TTY, so `--no-color` output is testable headlessly in CI.
+func renderBrowseSnapshot(models []api.ListModelResponse, cursor int, noColor bool) string {
+	m := browseModel{models: models, cursor: cursor, noColor: noColor}
+	return m.View()
+}
+
+// NewBrowseCmd returns the `ollama browse` subcommand, an interactive
+// terminal UI for browsing, running, and managing local models. Keyboard
+// shortcuts: up/down (or j/k) to move, s to toggle verbose show, r to run,
+// d to rm, c to cp (prompts for a destination name), p to push, q to quit.
+func NewBrowseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "browse",
+		Short: "Interactively browse local models",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := api.ClientFromEnvironment()
+			if err != nil {
+				return err
+			}
+
+			noColor, err := cmd.Flags().GetBool("no-color")
+			if err != nil {
+				return err
+			}
+
+			p := tea.NewProgram(newBrowseModel(client, noColor), tea.WithOutput(os.Stdout))
+			_, err = p.Run()
+			return err
+		},
+	}
+	cmd.Flags().Bool("no-color", false, "Disable colored output")
+	return cmd
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ollama/ollama/api"
+)
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "backspace":
+		return tea.KeyMsg{Type: tea.KeyBackspace}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+}
+
+func TestRenderBrowseSnapshot(t *testing.T) {
+	models := []api.ListModelResponse{
+		{Name: "llama3:8b", Size: 4 << 30, ModifiedAt: time.Now()},
+		{Name: "mistral:7b", Size: 4 << 30, ModifiedAt: time.Now()},
+	}
+
+	out := renderBrowseSnapshot(models, 1, true)
+
+	if !strings.Contains(out, "llama3:8b") || !strings.Contains(out, "mistral:7b") {
+		t.Fatalf("expected both models listed, got %q", out)
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("expected no ANSI escapes with --no-color, got %q", out)
+	}
+	if !strings.Contains(out, "> mistral:7b") {
+		t.Errorf("expected the cursor row to be marked, got %q", out)
+	}
+}
+
+func TestBrowseActionKeysProduceCommands(t *testing.T) {
+	models := []api.ListModelResponse{{Name: "llama3:8b"}, {Name: "mistral:7b"}}
+
+	for _, k := range []string{"r", "d", "p"} {
+		m := browseModel{models: models, cursor: 0}
+		_, cmd := m.Update(key(k))
+		if cmd == nil {
+			t.Errorf("expected key %q to produce a command", k)
+		}
+	}
+}
+
+func TestBrowseCopyInputFlow(t *testing.T) {
+	models := []api.ListModelResponse{{Name: "llama3:8b"}}
+	m := browseModel{models: models, cursor: 0}
+
+	updated, cmd := m.Update(key("c"))
+	m = updated.(browseModel)
+	if m.mode != browseModeCopyInput {
+		t.Fatalf("expected 'c' to enter copy-input mode")
+	}
+	if cmd != nil {
+		t.Errorf("expected entering copy-input mode to produce no command")
+	}
+
+	for _, r := range []string{"m", "y", "c", "o", "p", "y"} {
+		updated, _ = m.Update(key(r))
+		m = updated.(browseModel)
+	}
+	if m.copyTarget != "mycopy" {
+		t.Fatalf("expected typed destination %q, got %q", "mycopy", m.copyTarget)
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "copy to: mycopy") {
+		t.Errorf("expected the copy prompt to show the typed destination, got %q", view)
+	}
+
+	updated, _ = m.Update(key("backspace"))
+	m = updated.(browseModel)
+	if m.copyTarget != "mycop" {
+		t.Fatalf("expected backspace to remove the last rune, got %q", m.copyTarget)
+	}
+
+	updated, cmd = m.Update(key("enter"))
+	m = updated.(browseModel)
+	if m.mode != browseModeList {
+		t.Errorf("expected enter to return to list mode")
+	}
+	if cmd == nil {
+		t.Errorf("expected enter to dispatch the copy command")
+	}
+
+	m2 := browseModel{models: models, cursor: 0, mode: browseModeCopyInput, copyTarget: "abc"}
+	updated, cmd = m2.Update(key("esc"))
+	m2 = updated.(browseModel)
+	if m2.mode != browseModeList || m2.copyTarget != "" {
+		t.Errorf("expected esc to cancel and clear the typed destination")
+	}
+	if cmd != nil {
+		t.Errorf("expected esc to produce no command")
+	}
+}
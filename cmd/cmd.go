@@ -0,0 +1,484 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/ollama/ollama/api"
+	"github.com/ollama/ollama/format"
+	"github.com/ollama/ollama/progress"
+)
+
+// runOptions captures everything `ollama run` and `ollama create` need to
+// build a CreateRequest or drive an interactive session.
+type runOptions struct {
+	Model       string
+	ParentModel string
+	Prompt      string
+	Messages    []api.Message
+	WordWrap    bool
+	Format      string
+	System      string
+	Options     map[string]any
+}
+
+// NewCreateRequest builds the api.CreateRequest for `ollama create <name>`
+// from opts. A ParentModel that looks like a filesystem path (as happens
+// when a Modelfile's FROM was resolved relative to disk) is never a valid
+// "from" reference for the server, so it's ignored in favor of opts.Model.
+func NewCreateRequest(name string, opts runOptions) *api.CreateRequest {
+	from := opts.Model
+	if opts.ParentModel != "" && !looksLikeFilePath(opts.ParentModel) {
+		from = opts.ParentModel
+	}
+
+	req := &api.CreateRequest{
+		From:  from,
+		Model: name,
+	}
+
+	if opts.System != "" {
+		req.System = opts.System
+	}
+	if len(opts.Messages) > 0 {
+		req.Messages = opts.Messages
+	}
+	if len(opts.Options) > 0 {
+		req.Parameters = opts.Options
+	}
+
+	return req
+}
+
+func looksLikeFilePath(p string) bool {
+	if strings.HasPrefix(p, "/") {
+		return true
+	}
+	// Windows drive-letter path, e.g. "D:\foo" or "D:/foo".
+	return len(p) > 2 && p[1] == ':' && (p[2] == '\\' || p[2] == '/')
+}
+
+// getModelfileName resolves the --file flag to a Modelfile path, defaulting
+// to "Modelfile" in the current directory, and confirms it exists.
+func getModelfileName(cmd *cobra.Command) (string, error) {
+	filename, _ := cmd.Flags().GetString("file")
+	if filename == "" {
+		filename = "Modelfile"
+	}
+
+	if _, err := os.Stat(filename); err != nil {
+		return "", err
+	}
+
+	return filename, nil
+}
+
+// CreateHandler is the cobra RunE for `ollama create`.
+func CreateHandler(cmd *cobra.Command, args []string) error {
+	filename, err := getModelfileName(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to resolve modelfile: %w", err)
+	}
+
+	modelfile, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	client, err := api.ClientFromEnvironment()
+	if err != nil {
+		return err
+	}
+
+	req := &api.CreateRequest{
+		Model: args[0],
+		From:  parseFrom(string(modelfile)),
+	}
+
+	p := progress.NewProgress(os.Stderr)
+	defer p.Stop()
+
+	var status string
+	fn := func(resp api.ProgressResponse) error {
+		if resp.Status != status {
+			status = resp.Status
+			p.Add(status, progress.NewSpinner(status))
+		}
+		return nil
+	}
+
+	return client.Create(cmd.Context(), req, fn)
+}
+
+// parseFrom extracts the model referenced by a Modelfile's FROM line.
+func parseFrom(modelfile string) string {
+	for _, line := range strings.Split(modelfile, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && strings.EqualFold(fields[0], "FROM") {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// ListHandler is the cobra RunE for `ollama list`. args, if present, is
+// used as a name prefix filter.
+func ListHandler(cmd *cobra.Command, args []string) error {
+	client, err := api.ClientFromEnvironment()
+	if err != nil {
+		return err
+	}
+
+	var prefix string
+	if len(args) > 0 {
+		prefix = args[0]
+	}
+
+	models, err := client.List(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	matched := filterModels(models.Models, prefix)
+
+	if filters, _ := cmd.Flags().GetStringArray("filter"); len(filters) > 0 {
+		matched, err = applyFilters(matched, filters)
+		if err != nil {
+			return err
+		}
+	}
+
+	if sortBy, _ := cmd.Flags().GetString("sort"); sortBy != "" {
+		reverse, _ := cmd.Flags().GetBool("reverse")
+		if err := sortModels(matched, sortBy, reverse); err != nil {
+			return err
+		}
+	}
+
+	listFormat, _ := cmd.Flags().GetString("format")
+	return renderModelList(matched, listFormat)
+}
+
+func filterModels(models []api.ListModelResponse, prefix string) []api.ListModelResponse {
+	if prefix == "" {
+		return models
+	}
+
+	var out []api.ListModelResponse
+	for _, m := range models {
+		if strings.HasPrefix(m.Name, prefix) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func renderModelList(models []api.ListModelResponse, listFormat string) error {
+	switch {
+	case listFormat == "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(models)
+	case strings.HasPrefix(listFormat, "template="):
+		return renderModelTemplate(models, strings.TrimPrefix(listFormat, "template="))
+	}
+
+	var data [][]string
+	for _, m := range models {
+		digest := m.Digest
+		if len(digest) > 12 {
+			digest = digest[:12]
+		}
+		data = append(data, []string{m.Name, digest, format.HumanBytes(m.Size), format.HumanTime(m.ModifiedAt, "Never")})
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"NAME", "ID", "SIZE", "MODIFIED"})
+	table.SetAutoFormatHeaders(false)
+	table.SetBorder(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetColumnSeparator("")
+	table.SetNoWhiteSpace(true)
+	table.SetTablePadding("    ")
+	table.AppendBulk(data)
+	table.Render()
+
+	return nil
+}
+
+func renderModelTemplate(models []api.ListModelResponse, tmplText string) error {
+	tmpl, err := template.New("list").Parse(tmplText)
+	if err != nil {
+		return fmt.Errorf("invalid --format template: %w", err)
+	}
+
+	for _, m := range models {
+		if err := tmpl.Execute(os.Stdout, m); err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// stopModel unloads name by issuing a zero-keep-alive generate request, the
+// same mechanism `ollama stop` uses.
+func stopModel(ctx context.Context, client *api.Client, name string) error {
+	req := &api.GenerateRequest{Model: name, KeepAlive: &api.Duration{Duration: 0}}
+	return client.Generate(ctx, req, func(api.GenerateResponse) error { return nil })
+}
+
+// DeleteHandler is the cobra RunE for `ollama rm`. It accepts one or more
+// model names and stops each running model before deleting it.
+func DeleteHandler(cmd *cobra.Command, args []string) error {
+	client, err := api.ClientFromEnvironment()
+	if err != nil {
+		return err
+	}
+
+	return runParallel(cmd, args, func(ctx context.Context, name string) error {
+		if err := stopModel(ctx, client, name); err != nil {
+			return fmt.Errorf("unable to stop existing running model %q: %w", name, err)
+		}
+
+		return client.Delete(ctx, &api.DeleteRequest{Name: name})
+	})
+}
+
+// PushHandler is the cobra RunE for `ollama push`. It accepts one or more
+// model names and pushes each to the configured registry.
+func PushHandler(cmd *cobra.Command, args []string) error {
+	client, err := api.ClientFromEnvironment()
+	if err != nil {
+		return err
+	}
+
+	insecure, _ := cmd.Flags().GetBool("insecure")
+
+	p := progress.NewProgress(os.Stderr)
+	defer p.Stop()
+	var mu sync.Mutex
+
+	err = runParallel(cmd, args, func(ctx context.Context, name string) error {
+		return pushOne(ctx, client, name, insecure, p, &mu)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range args {
+		fmt.Printf("\nYou can find your model at:\n\n\thttps://ollama.com/%s\n", name)
+	}
+	return nil
+}
+
+// pushOne reports its progress through p rather than creating a renderer of
+// its own, so concurrent pushes from runParallel's worker pool draw onto one
+// shared multi-line display instead of each clobbering the terminal with its
+// own independent redraws. mu serializes access to p, which is not safe for
+// concurrent use.
+func pushOne(ctx context.Context, client *api.Client, name string, insecure bool, p *progress.Progress, mu *sync.Mutex) error {
+	bars := map[string]*progress.Bar{}
+	var status string
+	fn := func(resp api.ProgressResponse) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if resp.Digest != "" {
+			bar, ok := bars[resp.Digest]
+			if !ok {
+				bar = progress.NewBar(fmt.Sprintf("pushing %s: %s", name, resp.Digest), resp.Total, resp.Completed)
+				bars[resp.Digest] = bar
+				p.Add(name+" "+resp.Digest, bar)
+			}
+			bar.Set(resp.Completed)
+			return nil
+		}
+		if resp.Status != status {
+			status = resp.Status
+			p.Add(name+" "+status, progress.NewSpinner(fmt.Sprintf("%s: %s", name, status)))
+		}
+		return nil
+	}
+
+	req := &api.PushRequest{Name: name, Insecure: insecure}
+	if err := client.Push(ctx, req, fn); err != nil {
+		if strings.Contains(err.Error(), "access denied") {
+			return errors.New("you are not authorized to push to this namespace, create the model under a namespace you own")
+		}
+		return err
+	}
+	return nil
+}
+
+// PullHandler is the cobra RunE for `ollama pull`. It accepts one or more
+// model names and pulls each from the configured registry.
+func PullHandler(cmd *cobra.Command, args []string) error {
+	client, err := api.ClientFromEnvironment()
+	if err != nil {
+		return err
+	}
+
+	insecure, _ := cmd.Flags().GetBool("insecure")
+
+	p := progress.NewProgress(os.Stderr)
+	defer p.Stop()
+	var mu sync.Mutex
+
+	return runParallel(cmd, args, func(ctx context.Context, name string) error {
+		return pullOne(ctx, client, name, insecure, p, &mu)
+	})
+}
+
+// pullOne mirrors pushOne: it draws onto the shared p instead of opening its
+// own renderer, so `ollama pull --parallel N` with multiple models doesn't
+// interleave independent terminal redraws.
+func pullOne(ctx context.Context, client *api.Client, name string, insecure bool, p *progress.Progress, mu *sync.Mutex) error {
+	bars := map[string]*progress.Bar{}
+	var status string
+	fn := func(resp api.ProgressResponse) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if resp.Digest != "" {
+			bar, ok := bars[resp.Digest]
+			if !ok {
+				bar = progress.NewBar(fmt.Sprintf("pulling %s: %s", name, resp.Digest), resp.Total, resp.Completed)
+				bars[resp.Digest] = bar
+				p.Add(name+" "+resp.Digest, bar)
+			}
+			bar.Set(resp.Completed)
+			return nil
+		}
+		if resp.Status != status {
+			status = resp.Status
+			p.Add(name+" "+status, progress.NewSpinner(fmt.Sprintf("%s: %s", name, status)))
+		}
+		return nil
+	}
+
+	req := &api.PullRequest{Name: name, Insecure: insecure}
+	return client.Pull(ctx, req, fn)
+}
+
+// runParallel runs fn once per name in names. When the command has a
+// --parallel flag set above 1, the work is fanned out across a bounded
+// worker pool and a multi-model failure is reported as one joined error
+// instead of bailing out on the first one; otherwise names run in order,
+// matching the single-model behavior callers already depend on. Either way,
+// an interrupt (Ctrl-C) cancels the context passed to fn so in-flight
+// requests get a chance to unwind instead of leaving the terminal in a
+// half-drawn state.
+func runParallel(cmd *cobra.Command, names []string, fn func(ctx context.Context, name string) error) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	n := parallelism(cmd)
+	if n <= 1 || len(names) <= 1 {
+		for _, name := range names {
+			if err := fn(ctx, name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, n)
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+
+	for i, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(ctx, name); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", name, err)
+			}
+		}(i, name)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func parallelism(cmd *cobra.Command) int {
+	if f := cmd.Flags().Lookup("parallel"); f != nil {
+		if n, err := cmd.Flags().GetInt("parallel"); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 1
+}
+
+// NewListCmd returns the `ollama list` subcommand with filter, sort, and
+// format flags layered on top of the default name-prefix table output.
+func NewListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List models",
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    ListHandler,
+	}
+	cmd.Flags().StringArray("filter", nil, "Filter models (e.g. family=llama, size>1GB, modified<7d)")
+	cmd.Flags().String("sort", "", "Sort models by: name, size, modified")
+	cmd.Flags().Bool("reverse", false, "Reverse the sort order")
+	cmd.Flags().String("format", "", "Output format: json or template=<go-template>")
+	return cmd
+}
+
+// NewPushCmd returns the `ollama push` subcommand, accepting one or more
+// model names to push concurrently.
+func NewPushCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "push MODEL [MODEL...]",
+		Short: "Push a model to a registry",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  PushHandler,
+	}
+	cmd.Flags().Bool("insecure", false, "Allow insecure connections to the registry")
+	cmd.Flags().Int("parallel", 1, "Number of models to push concurrently")
+	return cmd
+}
+
+// NewPullCmd returns the `ollama pull` subcommand, accepting one or more
+// model names to pull concurrently.
+func NewPullCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pull MODEL [MODEL...]",
+		Short: "Pull a model from a registry",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  PullHandler,
+	}
+	cmd.Flags().Bool("insecure", false, "Allow insecure connections to the registry")
+	cmd.Flags().Int("parallel", 1, "Number of models to pull concurrently")
+	return cmd
+}
+
+// NewDeleteCmd returns the `ollama rm` subcommand, accepting one or more
+// model names to stop and delete concurrently.
+func NewDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rm MODEL [MODEL...]",
+		Aliases: []string{"delete"},
+		Short:   "Remove a model",
+		Args:    cobra.MinimumNArgs(1),
+		RunE:    DeleteHandler,
+	}
+	cmd.Flags().Int("parallel", 1, "Number of models to delete concurrently")
+	return cmd
+}
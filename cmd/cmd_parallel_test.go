@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ollama/ollama/api"
+)
+
+func TestPushHandlerParallel(t *testing.T) {
+	var mu sync.Mutex
+	pushed := map[string]bool{}
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/push" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+
+		var req api.PushRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		pushed[req.Name] = true
+		mu.Unlock()
+
+		for _, resp := range []api.ProgressResponse{
+			{Status: "preparing manifest"},
+			{Digest: "sha256:" + req.Name, Total: 10, Completed: 10},
+		} {
+			if err := json.NewEncoder(w).Encode(resp); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer mockServer.Close()
+
+	t.Setenv("OLLAMA_HOST", mockServer.URL)
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("insecure", false, "")
+	cmd.Flags().Int("parallel", 3, "")
+	cmd.SetContext(t.Context())
+
+	models := []string{"model-a", "model-b", "model-c"}
+	if err := PushHandler(cmd, models); err != nil {
+		t.Fatalf("PushHandler failed: %v", err)
+	}
+
+	for _, m := range models {
+		if !pushed[m] {
+			t.Errorf("expected %s to have been pushed", m)
+		}
+	}
+}
+
+func TestDeleteHandlerAggregatesErrors(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/generate" {
+			var req api.GenerateRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Model == "bad-model" {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(api.GenerateResponse{Done: false})
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(api.GenerateResponse{Done: true})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	t.Setenv("OLLAMA_HOST", mockServer.URL)
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Int("parallel", 2, "")
+	cmd.SetContext(t.Context())
+
+	err := DeleteHandler(cmd, []string{"good-model", "bad-model"})
+	if err == nil || !strings.Contains(err.Error(), "bad-model") {
+		t.Fatalf("expected aggregated error mentioning bad-model, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ollama/ollama/api"
+)
+
+// filterOp is one parsed --filter expression, e.g. "size>1GB" becomes
+// {field: "size", op: ">", value: "1GB"}.
+type filterOp struct {
+	field string
+	op    string
+	value string
+}
+
+var filterOperators = []string{">=", "<=", "!=", "=", ">", "<"}
+
+func parseFilterExpr(expr string) (filterOp, error) {
+	for _, op := range filterOperators {
+		if i := strings.Index(expr, op); i > 0 {
+			return filterOp{
+				field: strings.TrimSpace(expr[:i]),
+				op:    op,
+				value: strings.TrimSpace(expr[i+len(op):]),
+			}, nil
+		}
+	}
+	return filterOp{}, fmt.Errorf("invalid --filter expression %q: expected field<op>value", expr)
+}
+
+// applyFilters keeps only models matching every --filter expression given.
+// Supported fields are family, quant(ization), size, and modified, resolved
+// against api.ListModelResponse and its Details.
+func applyFilters(models []api.ListModelResponse, exprs []string) ([]api.ListModelResponse, error) {
+	ops := make([]filterOp, 0, len(exprs))
+	for _, expr := range exprs {
+		op, err := parseFilterExpr(expr)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+
+	var out []api.ListModelResponse
+	for _, m := range models {
+		matched := true
+		for _, op := range ops {
+			ok, err := matchFilter(m, op)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func matchFilter(m api.ListModelResponse, f filterOp) (bool, error) {
+	switch strings.ToLower(f.field) {
+	case "family":
+		return strings.EqualFold(m.Details.Family, f.value), nil
+	case "quant", "quantization":
+		return strings.EqualFold(m.Details.QuantizationLevel, f.value), nil
+	case "size":
+		bytes, err := parseByteSize(f.value)
+		if err != nil {
+			return false, err
+		}
+		return compareInt64(m.Size, f.op, bytes), nil
+	case "modified":
+		age, err := time.ParseDuration(normalizeDurationSuffix(f.value))
+		if err != nil {
+			return false, fmt.Errorf("invalid --filter modified value %q: %w", f.value, err)
+		}
+		cutoff := time.Now().Add(-age)
+		switch f.op {
+		case "<":
+			return m.ModifiedAt.After(cutoff), nil
+		case ">":
+			return m.ModifiedAt.Before(cutoff), nil
+		default:
+			return false, fmt.Errorf("--filter modified only supports < and >, got %q", f.op)
+		}
+	default:
+		return false, fmt.Errorf("unknown --filter field %q", f.field)
+	}
+}
+
+// normalizeDurationSuffix rewrites a shorthand day suffix ("7d") into
+// something time.ParseDuration accepts, since it has no day unit.
+func normalizeDurationSuffix(v string) string {
+	if strings.HasSuffix(v, "d") && !strings.HasSuffix(v, "ns") {
+		if n, err := strconv.Atoi(strings.TrimSuffix(v, "d")); err == nil {
+			return fmt.Sprintf("%dh", n*24)
+		}
+	}
+	return v
+}
+
+func parseByteSize(v string) (int64, error) {
+	v = strings.ToUpper(strings.TrimSpace(v))
+	units := []struct {
+		suffix string
+		mult   int64
+	}{
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	}
+	for _, u := range units {
+		if strings.HasSuffix(v, u.suffix) {
+			n, err := strconv.ParseFloat(strings.TrimSuffix(v, u.suffix), 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid size %q", v)
+			}
+			return int64(n * float64(u.mult)), nil
+		}
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", v)
+	}
+	return n, nil
+}
+
+func compareInt64(a int64, op string, b int64) bool {
+	switch op {
+	case ">":
+		return a > b
+	case "<":
+		return a < b
+	case ">=":
+		return a >= b
+	case "<=":
+		return a <= b
+	case "=":
+		return a == b
+	case "!=":
+		return a != b
+	}
+	return false
+}
+
+// sortModels orders models in place by field ("size", "name", or
+// "modified"), reversing the order when reverse is set.
+func sortModels(models []api.ListModelResponse, field string, reverse bool) error {
+	var less func(i, j int) bool
+	switch field {
+	case "name":
+		less = func(i, j int) bool { return models[i].Name < models[j].Name }
+	case "size":
+		less = func(i, j int) bool { return models[i].Size < models[j].Size }
+	case "modified":
+		less = func(i, j int) bool { return models[i].ModifiedAt.Before(models[j].ModifiedAt) }
+	default:
+		return fmt.Errorf("invalid --sort field %q: must be one of: name, size, modified", field)
+	}
+
+	sort.Slice(models, func(i, j int) bool {
+		if reverse {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+	return nil
+}
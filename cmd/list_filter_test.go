@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ollama/ollama/api"
+)
+
+func modelsFixture() []api.ListModelResponse {
+	return []api.ListModelResponse{
+		{
+			Name:       "llama3:8b",
+			Size:       2 << 30,
+			ModifiedAt: time.Now().Add(-time.Hour),
+			Details:    api.ModelDetails{Family: "llama", QuantizationLevel: "Q4_0"},
+		},
+		{
+			Name:       "mistral:7b",
+			Size:       500 << 20,
+			ModifiedAt: time.Now().Add(-240 * time.Hour),
+			Details:    api.ModelDetails{Family: "mistral", QuantizationLevel: "Q8_0"},
+		},
+	}
+}
+
+func TestApplyFiltersFamily(t *testing.T) {
+	out, err := applyFilters(modelsFixture(), []string{"family=llama"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 || out[0].Name != "llama3:8b" {
+		t.Fatalf("expected only llama3:8b, got %v", out)
+	}
+}
+
+func TestApplyFiltersSize(t *testing.T) {
+	out, err := applyFilters(modelsFixture(), []string{"size>1GB"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 || out[0].Name != "llama3:8b" {
+		t.Fatalf("expected only llama3:8b, got %v", out)
+	}
+}
+
+func TestApplyFiltersModified(t *testing.T) {
+	out, err := applyFilters(modelsFixture(), []string{"modified<7d"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 || out[0].Name != "llama3:8b" {
+		t.Fatalf("expected only llama3:8b, got %v", out)
+	}
+}
+
+func TestSortModels(t *testing.T) {
+	models := modelsFixture()
+	if err := sortModels(models, "size", false); err != nil {
+		t.Fatal(err)
+	}
+	if models[0].Name != "mistral:7b" {
+		t.Fatalf("expected mistral:7b first when sorted by size ascending, got %v", models)
+	}
+
+	if err := sortModels(models, "size", true); err != nil {
+		t.Fatal(err)
+	}
+	if models[0].Name != "llama3:8b" {
+		t.Fatalf("expected llama3:8b first when sorted by size descending, got %v", models)
+	}
+}
+
+func TestSortModelsInvalidField(t *testing.T) {
+	if err := sortModels(modelsFixture(), "bogus", false); err == nil {
+		t.Fatal("expected error for invalid sort field")
+	}
+}
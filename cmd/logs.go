@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ollama/ollama/app/lifecycle"
+)
+
+// readLines reads every line of f into memory. Log files are small enough
+// in practice (they're rotated well before this matters) that this is
+// simpler than streaming for the tail/since filtering below.
+func readLines(f *os.File) ([]string, error) {
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// showLogs prints the contents of path to stdout, or only the last tail
+// lines when tail > 0.
+func showLogs(path string, tail int) error {
+	return showLogsTo(os.Stdout, path, tail)
+}
+
+// showLogsTo is showLogs with the destination made explicit, so callers
+// that need the historical tail to go through something other than plain
+// stdout (e.g. followLogsMulti's prefixing writer) can reuse the same
+// read-and-truncate logic.
+func showLogsTo(w io.Writer, path string, tail int) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer f.Close()
+
+	lines, err := readLines(f)
+	if err != nil {
+		return fmt.Errorf("failed to read log file: %w", err)
+	}
+
+	if tail > 0 && len(lines) > tail {
+		lines = lines[len(lines)-tail:]
+	}
+
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+	return nil
+}
+
+// followLogs prints the last tail lines of path and then streams newly
+// appended lines until ctx is cancelled, similar to `tail -F`: renames,
+// truncation, and remove+recreate of path (as logrotate or a Windows
+// service restart would do) are all followed without losing output.
+func followLogs(ctx context.Context, path string, tail int) error {
+	if err := showLogs(path, tail); err != nil {
+		return err
+	}
+
+	return lifecycle.Tail(ctx, path, os.Stdout, lifecycle.TailOptions{
+		Follow:       true,
+		Rotate:       true,
+		PollInterval: 100 * time.Millisecond,
+	})
+}
+
+// followLogsFiltered is followLogs with a logFilter (--level/--since/
+// --grep/--json) applied to both the initial tail and every subsequently
+// streamed line.
+func followLogsFiltered(ctx context.Context, path string, tail int, f logFilter) error {
+	if err := showFilteredLogs(path, tail, f); err != nil {
+		return err
+	}
+
+	return lifecycle.Tail(ctx, path, newFilterWriter(os.Stdout, f), lifecycle.TailOptions{
+		Follow:       true,
+		Rotate:       true,
+		PollInterval: 100 * time.Millisecond,
+	})
+}
+
+// LogsHandler is the cobra RunE for `ollama logs`. It resolves the server
+// log path via the lifecycle package and either prints or follows it,
+// applying any --since/--level/--grep/--json filtering along the way. With
+// --all it follows server.log and app.log together and applies none of
+// those filters.
+func LogsHandler(cmd *cobra.Command, args []string) error {
+	tail, err := cmd.Flags().GetInt("tail")
+	if err != nil {
+		return err
+	}
+	follow, err := cmd.Flags().GetBool("follow")
+	if err != nil {
+		return err
+	}
+	since, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return err
+	}
+	level, err := cmd.Flags().GetString("level")
+	if err != nil {
+		return err
+	}
+	grep, err := cmd.Flags().GetString("grep")
+	if err != nil {
+		return err
+	}
+	jsonOut, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return err
+	}
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return err
+	}
+
+	if all {
+		if !follow {
+			return fmt.Errorf("--all requires --follow")
+		}
+		return followLogsMulti(cmd.Context(), []LogSource{
+			{Name: "server", Path: lifecycle.ServerLogFile},
+			{Name: "app", Path: lifecycle.AppLogFile},
+		}, tail)
+	}
+
+	f := logFilter{level: level, json: jsonOut}
+	if since != "" {
+		f.since, err = time.ParseDuration(since)
+		if err != nil {
+			return fmt.Errorf("invalid --since duration %q: %w", since, err)
+		}
+	}
+	if grep != "" {
+		f.grep, err = regexp.Compile(grep)
+		if err != nil {
+			return fmt.Errorf("invalid --grep pattern %q: %w", grep, err)
+		}
+	}
+
+	path := lifecycle.ServerLogFile
+
+	if follow {
+		if f.empty() {
+			return followLogs(cmd.Context(), path, tail)
+		}
+		return followLogsFiltered(cmd.Context(), path, tail, f)
+	}
+
+	if f.empty() {
+		return showLogs(path, tail)
+	}
+	return showFilteredLogs(path, tail, f)
+}
+
+// showFilteredLogs applies a logFilter on top of the plain tail behavior of
+// showLogs.
+func showFilteredLogs(path string, tail int, f logFilter) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	lines, err := readLines(file)
+	if err != nil {
+		return fmt.Errorf("failed to read log file: %w", err)
+	}
+
+	lines = filterLines(lines, f, !f.json && isTerminal(os.Stdout))
+
+	if tail > 0 && len(lines) > tail {
+		lines = lines[len(lines)-tail:]
+	}
+
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// NewLogsCmd returns the `ollama logs` subcommand for inspecting the
+// server log without hunting for its path per-OS.
+func NewLogsCmd() *cobra.Command {
+	logsCmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Show ollama server logs",
+		Args:  cobra.NoArgs,
+		RunE:  LogsHandler,
+	}
+
+	logsCmd.Flags().Int("tail", 0, "Number of lines to show from the end of the log")
+	logsCmd.Flags().BoolP("follow", "f", false, "Follow the log output")
+	logsCmd.Flags().String("since", "", "Only show logs newer than a duration (e.g. 15m)")
+	logsCmd.Flags().String("level", "", "Only show logs at or above this level")
+	logsCmd.Flags().String("grep", "", "Only show logs matching this regular expression")
+	logsCmd.Flags().Bool("json", false, "Pretty-print JSON-formatted log lines")
+	logsCmd.Flags().Bool("all", false, "Follow server.log and app.log together, prefixed by source")
+
+	return logsCmd
+}
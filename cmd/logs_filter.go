@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// logFilter is the parsed set of --since/--level/--grep/--json options
+// shared by the static and --follow code paths of `ollama logs`.
+type logFilter struct {
+	since time.Duration
+	level string
+	grep  *regexp.Regexp
+	json  bool
+}
+
+func (f logFilter) empty() bool {
+	return f.since == 0 && f.level == "" && f.grep == nil && !f.json
+}
+
+// levelColor returns the ANSI color code for level, or "" when none apply.
+func levelColor(level string) string {
+	switch strings.ToUpper(level) {
+	case "ERROR":
+		return "31" // red
+	case "WARN", "WARNING":
+		return "33" // yellow
+	case "INFO":
+		return "36" // cyan
+	case "DEBUG":
+		return "90" // gray
+	default:
+		return ""
+	}
+}
+
+// parseLogLine extracts a timestamp and level from a line, understanding
+// both Go's slog text handler (time=... level=...) and slog's JSON handler
+// ({"time":"...","level":"..."}).
+func parseLogLine(line string) (ts time.Time, level, message string, ok bool) {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") {
+		var fields map[string]any
+		if err := json.Unmarshal([]byte(trimmed), &fields); err == nil {
+			if t, ok := fields["time"].(string); ok {
+				ts, _ = time.Parse(time.RFC3339, t)
+			}
+			if l, ok := fields["level"].(string); ok {
+				level = l
+			}
+			if m, ok := fields["msg"].(string); ok {
+				message = m
+			}
+			return ts, level, message, true
+		}
+		return time.Time{}, "", "", false
+	}
+
+	for _, field := range strings.Fields(line) {
+		switch {
+		case strings.HasPrefix(field, "time="):
+			ts, _ = time.Parse(time.RFC3339, strings.Trim(strings.TrimPrefix(field, "time="), `"`))
+		case strings.HasPrefix(field, "level="):
+			level = strings.TrimPrefix(field, "level=")
+		case strings.HasPrefix(field, "msg="):
+			message = strings.Trim(strings.TrimPrefix(field, "msg="), `"`)
+		}
+	}
+	if ts.IsZero() {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			ts, _ = time.Parse(time.RFC3339, fields[0])
+		}
+	}
+	return ts, level, message, level != "" || !ts.IsZero()
+}
+
+// levelSeverity ranks the standard slog levels so --level can be compared
+// as "at or above" rather than an exact match. Unknown levels rank below
+// every known one, so an unparsed level never hides behind a high filter.
+func levelSeverity(level string) int {
+	switch strings.ToUpper(level) {
+	case "DEBUG":
+		return 0
+	case "INFO":
+		return 1
+	case "WARN", "WARNING":
+		return 2
+	case "ERROR":
+		return 3
+	default:
+		return -1
+	}
+}
+
+// matchesFilter reports whether line passes every active clause of f.
+func matchesFilter(line string, f logFilter, now time.Time) bool {
+	ts, level, _, parsed := parseLogLine(line)
+
+	if f.since > 0 && parsed && !ts.IsZero() && ts.Before(now.Add(-f.since)) {
+		return false
+	}
+	if f.level != "" && parsed && level != "" && levelSeverity(level) < levelSeverity(f.level) {
+		return false
+	}
+	if f.grep != nil && !f.grep.MatchString(line) {
+		return false
+	}
+	return true
+}
+
+// formatLine re-renders line for display: --json pretty-prints the
+// original structured payload (or passes it through if parsing fails), and
+// colorizes the level when color is enabled.
+func formatLine(line string, f logFilter, color bool) string {
+	if f.json {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "{") {
+			var buf bytes.Buffer
+			if err := json.Indent(&buf, []byte(trimmed), "", "  "); err == nil {
+				line = buf.String()
+			}
+		}
+	}
+
+	if !color {
+		return line
+	}
+
+	_, level, _, _ := parseLogLine(line)
+	if code := levelColor(level); code != "" {
+		return fmt.Sprintf("\x1b[%sm%s\x1b[0m", code, line)
+	}
+	return line
+}
+
+// filterLines applies f to lines, returning a new slice of only the ones
+// that pass, reformatted per formatLine.
+func filterLines(lines []string, f logFilter, color bool) []string {
+	now := time.Now()
+	var out []string
+	for _, line := range lines {
+		if matchesFilter(line, f, now) {
+			out = append(out, formatLine(line, f, color))
+		}
+	}
+	return out
+}
+
+// filterWriter applies a logFilter to a stream of appended log lines as
+// they arrive from a follower, buffering partial lines until a newline
+// completes them.
+type filterWriter struct {
+	w     io.Writer
+	f     logFilter
+	color bool
+	buf   bytes.Buffer
+}
+
+func newFilterWriter(w io.Writer, f logFilter) *filterWriter {
+	color := f.json == false && isTerminal(w)
+	return &filterWriter{w: w, f: f, color: color}
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func (fw *filterWriter) Write(p []byte) (int, error) {
+	fw.buf.Write(p)
+	for {
+		line, err := fw.buf.ReadString('\n')
+		if err != nil {
+			// Incomplete line: push it back and wait for more bytes.
+			fw.buf.WriteString(line)
+			break
+		}
+		trimmed := strings.TrimSuffix(line, "\n")
+		if matchesFilter(trimmed, fw.f, time.Now()) {
+			fmt.Fprintln(fw.w, formatLine(trimmed, fw.f, fw.color))
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestShowFilteredLogsGrep(t *testing.T) {
+	tempFile, err := os.CreateTemp(t.TempDir(), "ollama-logs-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := "level=INFO starting up\nlevel=ERROR cuda out of memory\n"
+	if _, err := tempFile.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = showFilteredLogs(tempFile.Name(), 0, logFilter{grep: regexp.MustCompile("cuda")})
+
+	w.Close()
+	os.Stdout = oldStdout
+	out, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if bytes.Contains(out, []byte("starting up")) {
+		t.Errorf("expected non-matching line to be filtered out, got %q", out)
+	}
+	if !bytes.Contains(out, []byte("cuda out of memory")) {
+		t.Errorf("expected matching line to be present, got %q", out)
+	}
+}
+
+func TestShowFilteredLogsJSON(t *testing.T) {
+	tempFile, err := os.CreateTemp(t.TempDir(), "ollama-logs-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := `{"time":"2026-07-27T00:00:00Z","level":"INFO","msg":"hello"}` + "\n"
+	if _, err := tempFile.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = showFilteredLogs(tempFile.Name(), 0, logFilter{json: true})
+
+	w.Close()
+	os.Stdout = oldStdout
+	out, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !bytes.Contains(out, []byte("\"msg\": \"hello\"")) {
+		t.Errorf("expected pretty-printed json, got %q", out)
+	}
+}
+
+func TestShowFilteredLogsLevelIsAtOrAbove(t *testing.T) {
+	tempFile, err := os.CreateTemp(t.TempDir(), "ollama-logs-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := "level=INFO starting up\nlevel=WARN disk getting full\nlevel=ERROR cuda out of memory\n"
+	if _, err := tempFile.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = showFilteredLogs(tempFile.Name(), 0, logFilter{level: "warn"})
+
+	w.Close()
+	os.Stdout = oldStdout
+	out, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if bytes.Contains(out, []byte("starting up")) {
+		t.Errorf("expected info line below the warn threshold to be filtered out, got %q", out)
+	}
+	if !bytes.Contains(out, []byte("disk getting full")) {
+		t.Errorf("expected warn line to be present, got %q", out)
+	}
+	if !bytes.Contains(out, []byte("cuda out of memory")) {
+		t.Errorf("expected error line above the warn threshold to be present, got %q", out)
+	}
+}
+
+func TestFilterWriterSplitsAcrossWrites(t *testing.T) {
+	var buf bytes.Buffer
+	fw := newFilterWriter(&buf, logFilter{grep: regexp.MustCompile("keep")})
+
+	fw.Write([]byte("drop this line\nkeep "))
+	fw.Write([]byte("this one\n"))
+
+	if bytes.Contains(buf.Bytes(), []byte("drop this line")) {
+		t.Errorf("expected filtered line to be dropped, got %q", buf.String())
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("keep this one")) {
+		t.Errorf("expected line split across writes to be reassembled, got %q", buf.String())
+	}
+}
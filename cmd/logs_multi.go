@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ollama/ollama/app/lifecycle"
+)
+
+// LogSource is one named log file to multiplex into a single followed
+// stream, e.g. {"server", lifecycle.ServerLogFile}.
+type LogSource struct {
+	Name string
+	Path string
+}
+
+// prefixColor assigns a stable ANSI color per source name so server/app
+// lines stay visually distinguishable without depending on line order.
+func prefixColor(name string) string {
+	switch name {
+	case "server":
+		return "36" // cyan
+	case "app":
+		return "35" // magenta
+	default:
+		return "32" // green
+	}
+}
+
+// multiWriter serializes writes from several tailed sources to a single
+// underlying writer through one mutex, so lines from different files never
+// interleave mid-line, and tags each line with a colorized [name] prefix.
+type multiWriter struct {
+	mu    sync.Mutex
+	w     *os.File
+	color bool
+}
+
+func (mw *multiWriter) writeLine(name, line string) {
+	prefix := fmt.Sprintf("[%s]", name)
+	if mw.color {
+		prefix = fmt.Sprintf("\x1b[%sm%s\x1b[0m", prefixColor(name), prefix)
+	}
+
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	fmt.Fprintf(mw.w, "%s %s\n", prefix, line)
+}
+
+// sourceWriter adapts multiWriter to io.Writer for a single named source,
+// buffering partial lines the same way filterWriter does.
+type sourceWriter struct {
+	name string
+	mw   *multiWriter
+	buf  []byte
+}
+
+func (sw *sourceWriter) Write(p []byte) (int, error) {
+	sw.buf = append(sw.buf, p...)
+	for {
+		i := indexByte(sw.buf, '\n')
+		if i < 0 {
+			break
+		}
+		sw.mw.writeLine(sw.name, string(sw.buf[:i]))
+		sw.buf = sw.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func indexByte(b []byte, c byte) int {
+	for i, v := range b {
+		if v == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// followLogsMulti concurrently tails every source in sources, printing the
+// last tail lines of each up front, then streaming new lines as they
+// arrive, every line tagged with a colorized [name] prefix. It blocks until
+// ctx is cancelled and every tailer has drained.
+func followLogsMulti(ctx context.Context, sources []LogSource, tail int) error {
+	mw := &multiWriter{w: os.Stdout, color: isTerminal(os.Stdout)}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(sources))
+
+	for _, src := range sources {
+		sw := &sourceWriter{name: src.Name, mw: mw}
+		if err := showLogsTo(sw, src.Path, tail); err != nil {
+			return fmt.Errorf("%s: %w", src.Name, err)
+		}
+	}
+
+	for i, src := range sources {
+		wg.Add(1)
+		go func(i int, src LogSource) {
+			defer wg.Done()
+			sw := &sourceWriter{name: src.Name, mw: mw}
+			errs[i] = lifecycle.Tail(ctx, src.Path, sw, lifecycle.TailOptions{
+				Follow:       true,
+				Rotate:       true,
+				PollInterval: 100 * time.Millisecond,
+			})
+		}(i, src)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
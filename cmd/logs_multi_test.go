@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestFollowLogsMultiPrefixesSources writes to two temp files standing in
+// for server.log and app.log and asserts lines from both arrive on stdout
+// tagged with their source's prefix.
+func TestFollowLogsMultiPrefixesSources(t *testing.T) {
+	serverFile, err := os.CreateTemp(t.TempDir(), "ollama-server-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintln(serverFile, "server already running")
+	serverFile.Close()
+
+	appFile, err := os.CreateTemp(t.TempDir(), "ollama-app-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintln(appFile, "app already open")
+	appFile.Close()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- followLogsMulti(ctx, []LogSource{
+			{Name: "server", Path: serverFile.Name()},
+			{Name: "app", Path: appFile.Name()},
+		}, 0)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	sf, err := os.OpenFile(serverFile.Name(), os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintln(sf, "server starting up")
+	sf.Close()
+
+	af, err := os.OpenFile(appFile.Name(), os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmt.Fprintln(af, "app window opened")
+	af.Close()
+
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("followLogsMulti returned error: %v", err)
+	}
+
+	w.Close()
+	os.Stdout = oldStdout
+	out, _ := io.ReadAll(r)
+
+	if !bytes.Contains(out, []byte("[server] server already running")) {
+		t.Errorf("expected the initial server tail to be prefixed, got %q", out)
+	}
+	if !bytes.Contains(out, []byte("[app] app already open")) {
+		t.Errorf("expected the initial app tail to be prefixed, got %q", out)
+	}
+	if !bytes.Contains(out, []byte("[server] server starting up")) {
+		t.Errorf("expected server-prefixed line, got %q", out)
+	}
+	if !bytes.Contains(out, []byte("[app] app window opened")) {
+		t.Errorf("expected app-prefixed line, got %q", out)
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestShowFilteredLogsSince(t *testing.T) {
+	tempFile, err := os.CreateTemp(t.TempDir(), "ollama-logs-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+	recent := time.Now().Format(time.RFC3339)
+	content := old + " level=INFO old line\n" + recent + " level=ERROR recent line\n"
+	if _, err := tempFile.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = showFilteredLogs(tempFile.Name(), 0, logFilter{since: time.Hour})
+
+	w.Close()
+	os.Stdout = oldStdout
+	out, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if bytes.Contains(out, []byte("old line")) {
+		t.Errorf("expected old line to be filtered out, got %q", out)
+	}
+	if !bytes.Contains(out, []byte("recent line")) {
+		t.Errorf("expected recent line to be present, got %q", out)
+	}
+}
+
+func TestShowFilteredLogsLevel(t *testing.T) {
+	tempFile, err := os.CreateTemp(t.TempDir(), "ollama-logs-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := "level=INFO starting up\nlevel=ERROR something broke\n"
+	if _, err := tempFile.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = showFilteredLogs(tempFile.Name(), 0, logFilter{level: "error"})
+
+	w.Close()
+	os.Stdout = oldStdout
+	out, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if bytes.Contains(out, []byte("starting up")) {
+		t.Errorf("expected info line to be filtered out, got %q", out)
+	}
+	if !bytes.Contains(out, []byte("something broke")) {
+		t.Errorf("expected error line to be present, got %q", out)
+	}
+}
+
+// TestFollowLogsDrainsOnCancel writes N lines immediately before cancel()
+// is called and asserts all N still reach stdout, covering the race between
+// the final write and followLogs's own shutdown.
+func TestFollowLogsDrainsOnCancel(t *testing.T) {
+	tempFile, err := os.CreateTemp(t.TempDir(), "ollama-follow-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tempFile.Close()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- followLogs(ctx, tempFile.Name(), 0)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	f, err := os.OpenFile(tempFile.Name(), os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const n = 10
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(f, "final line %d\n", i)
+	}
+	f.Close()
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("followLogs returned error: %v", err)
+	}
+
+	w.Close()
+	os.Stdout = oldStdout
+	out, _ := io.ReadAll(r)
+
+	for i := 0; i < n; i++ {
+		want := fmt.Sprintf("final line %d", i)
+		if !bytes.Contains(out, []byte(want)) {
+			t.Errorf("expected output to contain %q, got %q", want, string(out))
+		}
+	}
+}
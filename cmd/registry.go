@@ -0,0 +1,444 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// registryClient speaks the OCI/Ollama distribution protocol directly to a
+// registry, independent of a running `ollama serve`. It exists so power
+// users can mirror models between registries or script CI publishing
+// without a local daemon in the loop.
+type registryClient struct {
+	base     *url.URL
+	http     *http.Client
+	insecure bool
+
+	// bearer and basic are mutually exclusive; bearer wins if both are set.
+	bearer string
+	basicU string
+	basicP string
+}
+
+func newRegistryClient(host string, insecure bool) (*registryClient, error) {
+	if !strings.Contains(host, "://") {
+		scheme := "https"
+		if insecure {
+			scheme = "http"
+		}
+		host = scheme + "://" + host
+	}
+
+	base, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry host %q: %w", host, err)
+	}
+
+	rc := &registryClient{
+		base:     base,
+		http:     http.DefaultClient,
+		insecure: insecure,
+		bearer:   os.Getenv("OLLAMA_REGISTRY_TOKEN"),
+		basicU:   os.Getenv("OLLAMA_REGISTRY_USER"),
+		basicP:   os.Getenv("OLLAMA_REGISTRY_PASSWORD"),
+	}
+	return rc, nil
+}
+
+func (c *registryClient) newRequest(method, path string, body io.Reader) (*http.Request, error) {
+	u := *c.base
+	u.Path = path
+	return c.newRequestURL(method, u.String(), body)
+}
+
+// newLocationRequest builds a request against location, a URL handed back by
+// the registry itself (the chunked-upload Location header and its eventual
+// finalize target), which is opaque to us and typically carries a query
+// string. Routing it through newRequest's u.Path assignment would percent-
+// encode that query string into the path, so it's resolved as a proper URL
+// reference against c.base instead.
+func (c *registryClient) newLocationRequest(method, location string, body io.Reader) (*http.Request, error) {
+	ref, err := url.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry location %q: %w", location, err)
+	}
+	return c.newRequestURL(method, c.base.ResolveReference(ref).String(), body)
+}
+
+func (c *registryClient) newRequestURL(method, rawURL string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case c.bearer != "":
+		req.Header.Set("Authorization", "Bearer "+c.bearer)
+	case c.basicU != "":
+		req.SetBasicAuth(c.basicU, c.basicP)
+	}
+
+	return req, nil
+}
+
+func (c *registryClient) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("registry error: %s: %s", resp.Status, string(b))
+	}
+	return resp, nil
+}
+
+// blobStat checks whether digest already exists in repo, mirroring the
+// `HEAD /v2/<repo>/blobs/<digest>` existence check used before upload.
+func (c *registryClient) blobStat(repo, digest string) (int64, bool, error) {
+	req, err := c.newRequest(http.MethodHead, fmt.Sprintf("/v2/%s/blobs/%s", repo, digest), nil)
+	if err != nil {
+		return 0, false, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, false, nil
+	}
+	if resp.StatusCode >= 400 {
+		return 0, false, fmt.Errorf("registry error: %s", resp.Status)
+	}
+	return resp.ContentLength, true, nil
+}
+
+// blobPush uploads r (of size total, with expected digest) to repo using
+// chunked PATCH requests. Each chunk's Content-Range starts at the offset
+// reported by the registry's Range header on the previous response, when
+// present; r itself is read straight through from its current position and
+// is never repositioned to that offset. If the initial POST returns a
+// Docker-Upload-UUID, it's echoed back on every chunk request, since some
+// registries use it rather than the Location URL alone to correlate
+// requests with the upload session. Progress is streamed to stderr as it
+// goes.
+func (c *registryClient) blobPush(repo, digest string, r io.ReadSeeker, total int64) error {
+	if ok, exists, err := c.blobStat(repo, digest); err == nil && exists && ok == total {
+		fmt.Fprintf(os.Stderr, "blob %s already exists, skipping\n", digest)
+		return nil
+	}
+
+	req, err := c.newRequest(http.MethodPost, fmt.Sprintf("/v2/%s/blobs/uploads/", repo), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return fmt.Errorf("registry did not return an upload location")
+	}
+	uploadUUID := resp.Header.Get("Docker-Upload-UUID")
+
+	var offset int64
+	hasher := sha256.New()
+	buf := make([]byte, 1<<20)
+	for {
+		n, rerr := r.Read(buf)
+		if n > 0 {
+			hasher.Write(buf[:n])
+
+			chunkReq, err := c.newLocationRequest(http.MethodPatch, location, strings.NewReader(string(buf[:n])))
+			if err != nil {
+				return err
+			}
+			chunkReq.Header.Set("Content-Range", fmt.Sprintf("%d-%d", offset, offset+int64(n)-1))
+			chunkReq.Header.Set("Content-Type", "application/octet-stream")
+			if uploadUUID != "" {
+				chunkReq.Header.Set("Docker-Upload-UUID", uploadUUID)
+			}
+
+			chunkResp, err := c.do(chunkReq)
+			if err != nil {
+				return err
+			}
+			location = chunkResp.Header.Get("Location")
+			if v := chunkResp.Header.Get("Docker-Upload-UUID"); v != "" {
+				uploadUUID = v
+			}
+			if v := chunkResp.Header.Get("Range"); v != "" {
+				if parts := strings.SplitN(v, "-", 2); len(parts) == 2 {
+					if end, perr := strconv.ParseInt(parts[1], 10, 64); perr == nil {
+						offset = end + 1
+					}
+				}
+			} else {
+				offset += int64(n)
+			}
+			chunkResp.Body.Close()
+
+			fmt.Fprintf(os.Stderr, "\ruploading %s: %d/%d bytes", digest, offset, total)
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return rerr
+		}
+	}
+	fmt.Fprintln(os.Stderr)
+
+	if computed := "sha256:" + hex.EncodeToString(hasher.Sum(nil)); computed != digest {
+		return fmt.Errorf("digest mismatch: expected %s, got %s", digest, computed)
+	}
+
+	finalReq, err := c.newLocationRequest(http.MethodPut, location+"&digest="+url.QueryEscape(digest), nil)
+	if err != nil {
+		return err
+	}
+	finalResp, err := c.do(finalReq)
+	if err != nil {
+		return err
+	}
+	finalResp.Body.Close()
+	return nil
+}
+
+func (c *registryClient) manifestGet(repo, ref string) ([]byte, error) {
+	req, err := c.newRequest(http.MethodGet, fmt.Sprintf("/v2/%s/manifests/%s", repo, ref), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.oci.image.manifest.v1+json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func (c *registryClient) manifestPut(repo, ref string, manifest []byte) error {
+	req, err := c.newRequest(http.MethodPut, fmt.Sprintf("/v2/%s/manifests/%s", repo, ref), strings.NewReader(string(manifest)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+type catalogResponse struct {
+	Repositories []string `json:"repositories"`
+}
+
+func (c *registryClient) catalog() ([]string, error) {
+	req, err := c.newRequest(http.MethodGet, "/v2/_catalog", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var out catalogResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return out.Repositories, nil
+}
+
+type tagsResponse struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+func (c *registryClient) tags(repo string) ([]string, error) {
+	req, err := c.newRequest(http.MethodGet, fmt.Sprintf("/v2/%s/tags/list", repo), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var out tagsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return out.Tags, nil
+}
+
+func registryClientFromCmd(cmd *cobra.Command, host string) (*registryClient, error) {
+	insecure, err := cmd.Flags().GetBool("insecure")
+	if err != nil {
+		return nil, err
+	}
+	return newRegistryClient(host, insecure)
+}
+
+// NewRegistryCmd returns the `ollama registry` subcommand tree exposing raw
+// distribution operations against a remote registry, bypassing the local
+// daemon entirely.
+func NewRegistryCmd() *cobra.Command {
+	registryCmd := &cobra.Command{
+		Use:   "registry",
+		Short: "Low-level operations against an OCI/Ollama registry",
+	}
+	registryCmd.PersistentFlags().Bool("insecure", false, "Allow plain HTTP to the registry")
+
+	blobCmd := &cobra.Command{Use: "blob", Short: "Blob operations"}
+	blobCmd.AddCommand(
+		&cobra.Command{
+			Use:   "stat <host> <repo> <digest>",
+			Short: "Check whether a blob exists in the registry",
+			Args:  cobra.ExactArgs(3),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				c, err := registryClientFromCmd(cmd, args[0])
+				if err != nil {
+					return err
+				}
+				size, ok, err := c.blobStat(args[1], args[2])
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return fmt.Errorf("blob %s not found", args[2])
+				}
+				fmt.Printf("%s: %d bytes\n", args[2], size)
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "push <host> <repo> <path> <digest>",
+			Short: "Upload a local file as a blob",
+			Args:  cobra.ExactArgs(4),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				c, err := registryClientFromCmd(cmd, args[0])
+				if err != nil {
+					return err
+				}
+				f, err := os.Open(args[2])
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				fi, err := f.Stat()
+				if err != nil {
+					return err
+				}
+				return c.blobPush(args[1], args[3], f, fi.Size())
+			},
+		},
+	)
+
+	manifestCmd := &cobra.Command{Use: "manifest", Short: "Manifest operations"}
+	manifestCmd.AddCommand(
+		&cobra.Command{
+			Use:   "get <host> <repo> <reference>",
+			Short: "Fetch a manifest",
+			Args:  cobra.ExactArgs(3),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				c, err := registryClientFromCmd(cmd, args[0])
+				if err != nil {
+					return err
+				}
+				b, err := c.manifestGet(args[1], args[2])
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(b))
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "put <host> <repo> <reference> <path>",
+			Short: "Upload a manifest",
+			Args:  cobra.ExactArgs(4),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				c, err := registryClientFromCmd(cmd, args[0])
+				if err != nil {
+					return err
+				}
+				b, err := os.ReadFile(args[3])
+				if err != nil {
+					return err
+				}
+				return c.manifestPut(args[1], args[2], b)
+			},
+		},
+	)
+
+	catalogCmd := &cobra.Command{
+		Use:   "catalog <host>",
+		Short: "List repositories on the registry",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := registryClientFromCmd(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			repos, err := c.catalog()
+			if err != nil {
+				return err
+			}
+			for _, r := range repos {
+				fmt.Println(r)
+			}
+			return nil
+		},
+	}
+
+	tagsCmd := &cobra.Command{
+		Use:   "tags <host> <repo>",
+		Short: "List tags for a repository",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := registryClientFromCmd(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			tags, err := c.tags(args[1])
+			if err != nil {
+				return err
+			}
+			for _, t := range tags {
+				fmt.Println(t)
+			}
+			return nil
+		},
+	}
+
+	registryCmd.AddCommand(blobCmd, manifestCmd, catalogCmd, tagsCmd)
+	return registryCmd
+}
@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ollama/ollama/api"
+)
+
+// showInfoOutput is the stable, machine-readable schema emitted by
+// `ollama show --format json|yaml`. Field names are snake_case so they
+// read naturally as JSON/YAML and won't change across table-layout tweaks.
+type showInfoOutput struct {
+	Details       api.ModelDetails `json:"details" yaml:"details"`
+	ModelInfo     map[string]any   `json:"model_info,omitempty" yaml:"model_info,omitempty"`
+	Parameters    map[string]string `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Tensors       []api.Tensor     `json:"tensors,omitempty" yaml:"tensors,omitempty"`
+	ProjectorInfo map[string]any   `json:"projector_info,omitempty" yaml:"projector_info,omitempty"`
+	Capabilities  []string         `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+	System        string           `json:"system,omitempty" yaml:"system,omitempty"`
+	License       string           `json:"license,omitempty" yaml:"license,omitempty"`
+}
+
+// parseParameters turns the newline-delimited "key value" Modelfile
+// parameter block into a map of key to value. Keys
+// like "stop" may repeat; only the last occurrence survives, which matches
+// how the server resolves duplicate parameters.
+func parseParameters(s string) map[string]string {
+	out := map[string]string{}
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		out[fields[0]] = strings.Join(fields[1:], " ")
+	}
+	return out
+}
+
+func newShowInfoOutput(resp *api.ShowResponse) showInfoOutput {
+	caps := make([]string, 0, len(resp.Capabilities))
+	for _, c := range resp.Capabilities {
+		caps = append(caps, string(c))
+	}
+
+	return showInfoOutput{
+		Details:       resp.Details,
+		ModelInfo:     resp.ModelInfo,
+		Parameters:    parseParameters(resp.Parameters),
+		Tensors:       resp.Tensors,
+		ProjectorInfo: resp.ProjectorInfo,
+		Capabilities:  caps,
+		System:        resp.System,
+		License:       resp.License,
+	}
+}
+
+// showInfo renders the existing human-readable table output. It remains the
+// default when --format is not given.
+func showInfo(resp *api.ShowResponse, verbose bool, w io.Writer) error {
+	tableRender := func(header string, rows func() [][]string) {
+		fmt.Fprintln(w, "  "+header)
+		table := tabwriter.NewWriter(w, 0, 0, 4, ' ', 0)
+		for _, row := range rows() {
+			fmt.Fprintf(table, "    %s\t\n", strings.Join(row, "\t"))
+		}
+		table.Flush()
+		fmt.Fprintln(w)
+	}
+
+	tableRender("Model", func() (rows [][]string) {
+		if arch, ok := resp.ModelInfo["general.architecture"].(string); ok {
+			rows = append(rows, []string{"architecture", arch})
+		} else {
+			rows = append(rows, []string{"architecture", resp.Details.Family})
+		}
+
+		rows = append(rows, []string{"parameters", resp.Details.ParameterSize})
+
+		if arch, ok := resp.ModelInfo["general.architecture"].(string); ok {
+			if v, ok := resp.ModelInfo[arch+".context_length"]; ok {
+				rows = append(rows, []string{"context length", fmt.Sprintf("%v", v)})
+			}
+			if v, ok := resp.ModelInfo[arch+".embedding_length"]; ok {
+				rows = append(rows, []string{"embedding length", fmt.Sprintf("%v", v)})
+			}
+		}
+
+		rows = append(rows, []string{"quantization", resp.Details.QuantizationLevel})
+		return
+	})
+
+	if resp.Parameters != "" {
+		tableRender("Parameters", func() (rows [][]string) {
+			for _, line := range strings.Split(resp.Parameters, "\n") {
+				fields := strings.Fields(line)
+				if len(fields) < 2 {
+					continue
+				}
+				rows = append(rows, []string{fields[0], strings.Join(fields[1:], " ")})
+			}
+			return
+		})
+	}
+
+	if resp.System != "" {
+		tableRender("System", func() (rows [][]string) {
+			lines := strings.Split(strings.TrimSpace(resp.System), "\n")
+			for i, line := range lines {
+				if i >= 2 {
+					rows = append(rows, []string{"..."})
+					break
+				}
+				rows = append(rows, []string{strings.TrimSpace(line)})
+			}
+			return
+		})
+	}
+
+	if resp.License != "" {
+		tableRender("License", func() (rows [][]string) {
+			for _, line := range strings.Split(strings.TrimSpace(resp.License), "\n") {
+				rows = append(rows, []string{strings.TrimSpace(line)})
+			}
+			return
+		})
+	}
+
+	if len(resp.ProjectorInfo) > 0 {
+		tableRender("Projector", func() (rows [][]string) {
+			arch, _ := resp.ProjectorInfo["general.architecture"].(string)
+			rows = append(rows, []string{"architecture", arch})
+			if v, ok := resp.ProjectorInfo["general.parameter_count"].(float64); ok {
+				rows = append(rows, []string{"parameters", formatParams(v)})
+			}
+			if v, ok := resp.ProjectorInfo[arch+".vision.embedding_length"]; ok {
+				rows = append(rows, []string{"embedding length", fmt.Sprintf("%v", v)})
+			}
+			if v, ok := resp.ProjectorInfo[arch+".vision.projection_dim"]; ok {
+				rows = append(rows, []string{"dimensions", fmt.Sprintf("%v", v)})
+			}
+			return
+		})
+	}
+
+	if verbose && len(resp.ModelInfo) > 0 {
+		tableRender("Metadata", func() (rows [][]string) {
+			keys := make([]string, 0, len(resp.ModelInfo))
+			for k := range resp.ModelInfo {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				rows = append(rows, []string{k, fmt.Sprintf("%v", resp.ModelInfo[k])})
+			}
+			return
+		})
+	}
+
+	if verbose && len(resp.Tensors) > 0 {
+		tableRender("Tensors", func() (rows [][]string) {
+			for _, t := range resp.Tensors {
+				rows = append(rows, []string{t.Name, fmt.Sprintf("%s\t%v", t.Type, t.Shape)})
+			}
+			return
+		})
+	}
+
+	if len(resp.Capabilities) > 0 {
+		tableRender("Capabilities", func() (rows [][]string) {
+			for _, c := range resp.Capabilities {
+				rows = append(rows, []string{string(c)})
+			}
+			return
+		})
+	}
+
+	return nil
+}
+
+func formatParams(n float64) string {
+	switch {
+	case n >= 1e9:
+		return fmt.Sprintf("%.2fB", n/1e9)
+	case n >= 1e6:
+		return fmt.Sprintf("%.2fM", n/1e6)
+	default:
+		return fmt.Sprintf("%.0f", n)
+	}
+}
+
+// showInfoFormatted writes resp to w as json or yaml per format, or falls
+// back to showInfo's table rendering when format is empty. An unrecognized
+// format is a usage error rather than a silent fallback, so scripts don't
+// mistake a typo'd flag for the default table.
+func showInfoFormatted(resp *api.ShowResponse, verbose bool, format string, w io.Writer) error {
+	switch format {
+	case "":
+		return showInfo(resp, verbose, w)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(newShowInfoOutput(resp))
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		defer enc.Close()
+		return enc.Encode(newShowInfoOutput(resp))
+	default:
+		return fmt.Errorf("invalid format %q: must be one of: json, yaml", format)
+	}
+}
+
+// ShowHandler is the cobra RunE for `ollama show`. It resolves the model's
+// metadata from the running server and renders it per --format, defaulting
+// to the existing human-readable table.
+func ShowHandler(cmd *cobra.Command, args []string) error {
+	client, err := api.ClientFromEnvironment()
+	if err != nil {
+		return err
+	}
+
+	verbose, err := cmd.Flags().GetBool("verbose")
+	if err != nil {
+		return err
+	}
+
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Show(cmd.Context(), &api.ShowRequest{Name: args[0]})
+	if err != nil {
+		return err
+	}
+
+	return showInfoFormatted(resp, verbose, format, os.Stdout)
+}
+
+// NewShowCmd returns the `ollama show` subcommand for displaying a model's
+// details, optionally as machine-readable json or yaml via --format.
+func NewShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show MODEL",
+		Short: "Show information for a model",
+		Args:  cobra.ExactArgs(1),
+		RunE:  ShowHandler,
+	}
+	cmd.Flags().Bool("verbose", false, "Show detailed model information")
+	cmd.Flags().String("format", "", "Output format: json or yaml")
+	return cmd
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ollama/ollama/api"
+)
+
+func TestShowInfoFormatted(t *testing.T) {
+	resp := &api.ShowResponse{
+		Details: api.ModelDetails{
+			Family:            "test",
+			ParameterSize:     "7B",
+			QuantizationLevel: "FP16",
+		},
+		Parameters: "stop up",
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var b bytes.Buffer
+		if err := showInfoFormatted(resp, false, "json", &b); err != nil {
+			t.Fatal(err)
+		}
+
+		var out showInfoOutput
+		if err := json.Unmarshal(b.Bytes(), &out); err != nil {
+			t.Fatalf("output is not valid json: %v", err)
+		}
+
+		if out.Details.ParameterSize != "7B" {
+			t.Errorf("expected parameter size 7B, got %s", out.Details.ParameterSize)
+		}
+		if out.Parameters["stop"] != "up" {
+			t.Errorf("expected parsed parameter stop=up, got %q", out.Parameters["stop"])
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		var b bytes.Buffer
+		if err := showInfoFormatted(resp, false, "yaml", &b); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Contains(b.Bytes(), []byte("7B")) {
+			t.Errorf("expected yaml output to contain the parameter size, got %s", b.String())
+		}
+	})
+
+	t.Run("default falls back to table", func(t *testing.T) {
+		var b bytes.Buffer
+		if err := showInfoFormatted(resp, false, "", &b); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Contains(b.Bytes(), []byte("Model")) {
+			t.Errorf("expected table output, got %s", b.String())
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		var b bytes.Buffer
+		err := showInfoFormatted(resp, false, "xml", &b)
+		if err == nil {
+			t.Fatal("expected error for invalid format")
+		}
+	})
+}
+
+// TestNewShowCmdRegistersFormatFlag guards against `ollama show --format`
+// having no flag to parse: ShowHandler reads --format/--verbose, so the
+// command built by NewShowCmd must actually declare them.
+func TestNewShowCmdRegistersFormatFlag(t *testing.T) {
+	cmd := NewShowCmd()
+
+	if cmd.Flags().Lookup("format") == nil {
+		t.Error("expected NewShowCmd to register a --format flag")
+	}
+	if cmd.Flags().Lookup("verbose") == nil {
+		t.Error("expected NewShowCmd to register a --verbose flag")
+	}
+	if cmd.Use != "show MODEL" {
+		t.Errorf("expected Use %q, got %q", "show MODEL", cmd.Use)
+	}
+}